@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/RomanosTrechlis/blog-gen/devserver"
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+// serveCmd implements `blog-gen serve`: a live-reload dev server built on
+// top of the regular generator pipeline.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the site locally with live reload",
+	Long:  "Builds the site to a temp directory, serves it over HTTP, and rebuilds it whenever content or templates change. Changes to the site config are not watched.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srv, err := devserver.New(siteInfo, devserver.Options{
+			Addr:        serveAddr,
+			ContentDir:  siteInfo.ContentFolder,
+			TemplateDir: siteInfo.TemplateFolder,
+		})
+		if err != nil {
+			return err
+		}
+		return srv.Run(context.Background())
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "address to bind the dev server to")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,44 @@
+// Package config holds the site-wide settings threaded through the
+// generator, template and devserver packages.
+package config
+
+// SiteInformation describes a single blog-gen site: where its source lives,
+// where its output goes, and how it should be built.
+type SiteInformation struct {
+	// ContentFolder holds one subdirectory per post (post.md, front-matter,
+	// an optional images/ dir and an optional artifacts/ dir).
+	ContentFolder string
+	// TemplateFolder holds the html/template files used to render pages.
+	TemplateFolder string
+	// TempFolder is scratch space used while building a post, e.g. for
+	// artifacts staged before copyAdditionalArtifacts runs.
+	TempFolder string
+	// StaticFolder holds site-wide assets (css/js) that templates reference
+	// via the `asset` template func, e.g. StaticFolder/css/main.css. Optional;
+	// sites with no site-wide assets can leave it empty.
+	StaticFolder string
+	// Destination is where the generated site is written.
+	Destination string
+	// MarkdownExtensions controls which optional goldmark extensions and
+	// code highlighter a site opts into.
+	MarkdownExtensions MarkdownExtensions
+	// Title, BaseURL, Author and Description identify the site in its
+	// generated feeds and sitemap.
+	Title       string
+	BaseURL     string
+	Author      string
+	Description string
+	// PoolSize bounds how many posts are generated concurrently. Zero
+	// means "use GOMAXPROCS".
+	PoolSize int
+}
+
+// MarkdownExtensions toggles the optional parts of the markdown pipeline.
+// GFM, front-matter and heading IDs are always enabled; these are opt-in.
+type MarkdownExtensions struct {
+	Emoji   bool
+	Mermaid bool
+	// Highlighter selects the code highlighter: markdown.HighlighterChroma
+	// (default, via goldmark) or markdown.HighlighterLegacy.
+	Highlighter string
+}
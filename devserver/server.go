@@ -0,0 +1,246 @@
+// Package devserver implements `blog-gen serve`, a live-reload development
+// server built on top of the regular generator pipeline. It builds the site
+// into a temporary directory, serves it over HTTP, and watches the source
+// directories for changes so it can rebuild and notify connected browsers.
+package devserver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/RomanosTrechlis/blog-gen/config"
+	"github.com/RomanosTrechlis/blog-gen/generator"
+)
+
+// Options configures a dev server run.
+type Options struct {
+	// Addr is the address the HTTP server binds to, e.g. ":8080".
+	Addr string
+	// ContentDir, TemplateDir and ConfigDir are watched for changes.
+	ContentDir  string
+	TemplateDir string
+	ConfigDir   string
+}
+
+// Server serves a site built by the generator package and rebuilds it
+// whenever a watched source file changes, pushing a reload notice to every
+// connected browser over Server-Sent Events.
+type Server struct {
+	opts     Options
+	siteInfo *config.SiteInformation
+	tempDir  string
+
+	mu      sync.Mutex
+	clients map[chan struct{}]bool
+
+	// done is closed once, by shutdown, to unblock any handleLiveReload
+	// goroutines still waiting on a client connection.
+	done chan struct{}
+}
+
+// shutdownTimeout bounds how long shutdown waits for in-flight requests
+// (including long-lived SSE connections) to finish before giving up.
+const shutdownTimeout = 5 * time.Second
+
+// New creates a Server that will build siteInfo into a fresh temp directory
+// and serve it according to opts.
+func New(siteInfo *config.SiteInformation, opts Options) (*Server, error) {
+	tempDir, err := ioutil.TempDir("", "blog-gen-serve-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temp directory for dev server: %v", err)
+	}
+	if opts.Addr == "" {
+		opts.Addr = ":8080"
+	}
+	return &Server{
+		opts:     opts,
+		siteInfo: siteInfo,
+		tempDir:  tempDir,
+		clients:  make(map[chan struct{}]bool),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Run builds the site, starts the HTTP server and watcher, and blocks until
+// ctx is cancelled or a SIGINT/SIGTERM is received, at which point it shuts
+// down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	generator.DevMode = true
+	defer func() { generator.DevMode = false }()
+	defer os.RemoveAll(s.tempDir)
+
+	if err := s.build(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating file watcher: %v", err)
+	}
+	defer watcher.Close()
+	for _, dir := range []string{s.opts.ContentDir, s.opts.TemplateDir, s.opts.ConfigDir} {
+		if dir == "" {
+			continue
+		}
+		if err := addWatchRecursive(watcher, dir); err != nil {
+			return fmt.Errorf("error watching %s: %v", dir, err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_livereload", s.handleLiveReload)
+	mux.Handle("/", http.FileServer(http.Dir(s.tempDir)))
+	srv := &http.Server{Addr: s.opts.Addr, Handler: mux}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	errCh := make(chan error, 1)
+	go func() {
+		fmt.Printf("Serving site on %s (watching %s)...\n", s.opts.Addr, s.opts.ContentDir)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	for {
+		select {
+		case event := <-watcher.Events:
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+				continue
+			}
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addWatchRecursive(watcher, event.Name); err != nil {
+						fmt.Printf("\terror watching new directory %s: %v\n", event.Name, err)
+					}
+				}
+			}
+			fmt.Printf("\tChange detected at %s, rebuilding...\n", event.Name)
+			if err := s.rebuild(event.Name); err != nil {
+				fmt.Printf("\terror rebuilding site: %v\n", err)
+				continue
+			}
+			s.notifyClients()
+		case err := <-watcher.Errors:
+			fmt.Printf("\twatcher error: %v\n", err)
+		case err := <-errCh:
+			return err
+		case <-sigCh:
+			return s.shutdown(srv)
+		case <-ctx.Done():
+			return s.shutdown(srv)
+		}
+	}
+}
+
+func (s *Server) shutdown(srv *http.Server) error {
+	fmt.Println("Shutting down dev server...")
+	close(s.done)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
+
+func (s *Server) build() error {
+	s.siteInfo.Destination = s.tempDir
+	return generator.Generate(s.siteInfo)
+}
+
+// rebuild regenerates just the post affected by changedPath when possible,
+// falling back to a full site build for template/config changes or changes
+// outside any single post directory (e.g. a post added or removed).
+func (s *Server) rebuild(changedPath string) error {
+	s.siteInfo.Destination = s.tempDir
+	if postDir, ok := postDirFor(s.opts.ContentDir, changedPath); ok {
+		return generator.GeneratePost(s.siteInfo, postDir)
+	}
+	return generator.Generate(s.siteInfo)
+}
+
+// postDirFor returns the immediate post subdirectory of contentDir that
+// changedPath falls under, if any.
+func postDirFor(contentDir, changedPath string) (string, bool) {
+	if contentDir == "" {
+		return "", false
+	}
+	rel, err := filepath.Rel(contentDir, changedPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	if len(parts) < 2 {
+		return "", false
+	}
+	return filepath.Join(contentDir, parts[0]), true
+}
+
+// addWatchRecursive adds dir and every subdirectory beneath it to watcher;
+// fsnotify only watches the directory it's given, not its descendants, and
+// blog-gen posts live in per-post subdirectories under the content dir.
+func addWatchRecursive(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// handleLiveReload serves the SSE stream that generated pages subscribe to.
+func (s *Server) handleLiveReload(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.clients[ch] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, ch)
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ch:
+			fmt.Fprintf(w, "data: reload\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Server) notifyClients() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
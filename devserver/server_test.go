@@ -0,0 +1,76 @@
+package devserver
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPostDirFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		contentDir string
+		changed    string
+		wantDir    string
+		wantOK     bool
+	}{
+		{
+			name:       "file inside a post directory",
+			contentDir: "/site/content",
+			changed:    "/site/content/hello-world/post.md",
+			wantDir:    "/site/content/hello-world",
+			wantOK:     true,
+		},
+		{
+			name:       "file inside a post's images directory",
+			contentDir: "/site/content",
+			changed:    "/site/content/hello-world/images/a.png",
+			wantDir:    "/site/content/hello-world",
+			wantOK:     true,
+		},
+		{
+			name:       "the content directory itself",
+			contentDir: "/site/content",
+			changed:    "/site/content",
+			wantOK:     false,
+		},
+		{
+			name:       "a path outside the content directory",
+			contentDir: "/site/content",
+			changed:    "/site/templates/post.html",
+			wantOK:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, ok := postDirFor(tt.contentDir, tt.changed)
+			if ok != tt.wantOK {
+				t.Fatalf("postDirFor(%q, %q) ok = %v, want %v", tt.contentDir, tt.changed, ok, tt.wantOK)
+			}
+			if ok && dir != tt.wantDir {
+				t.Fatalf("postDirFor(%q, %q) = %q, want %q", tt.contentDir, tt.changed, dir, tt.wantDir)
+			}
+		})
+	}
+}
+
+func TestHandleLiveReloadUnblocksOnShutdown(t *testing.T) {
+	s := &Server{clients: make(map[chan struct{}]bool), done: make(chan struct{})}
+
+	req := httptest.NewRequest("GET", "/_livereload", nil)
+	rec := httptest.NewRecorder()
+
+	handlerDone := make(chan struct{})
+	go func() {
+		s.handleLiveReload(rec, req)
+		close(handlerDone)
+	}()
+
+	close(s.done)
+
+	select {
+	case <-handlerDone:
+	case <-time.After(time.Second):
+		t.Fatal("handleLiveReload did not return after done was closed")
+	}
+}
@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/RomanosTrechlis/blog-gen/config"
+	"github.com/RomanosTrechlis/blog-gen/util/fs"
+)
+
+// assetInfo is what fingerprintFile records about a copied asset.
+type assetInfo struct {
+	// hashedPath is the asset's path after fingerprinting, relative to
+	// the site root, e.g. "css/main.a1b2c3d4.css".
+	hashedPath string
+	// integrity is the asset's SRI digest, e.g. "sha256-<base64>".
+	integrity string
+}
+
+// assetHashes maps an asset's original path (relative to the site root) to
+// its assetInfo. It is populated as assets are copied and queried by the
+// `asset` template func so templates can reference the hashed name.
+var assetHashes = struct {
+	sync.Mutex
+	m map[string]assetInfo
+}{m: map[string]assetInfo{}}
+
+// asset returns the fingerprinted path for originalPath, or originalPath
+// itself if it was never hashed. Registered as a template func so layouts
+// can write `{{ asset "css/main.css" }}`.
+func asset(originalPath string) string {
+	assetHashes.Lock()
+	defer assetHashes.Unlock()
+	if info, ok := assetHashes.m[originalPath]; ok {
+		return info.hashedPath
+	}
+	return originalPath
+}
+
+// fingerprintFile copies src into destDir under a name that embeds the
+// first 8 hex characters of its SHA-256 hash, e.g. "main.css" ->
+// "main.a1b2c3d4.css", and records the mapping and its full SRI digest in
+// assetHashes keyed by relPath (the asset's path relative to the site root).
+func fingerprintFile(src, destDir, relPath string) (hashedRelPath string, err error) {
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		return "", fmt.Errorf("error reading asset %s: %v", src, err)
+	}
+	sum := sha256.Sum256(data)
+
+	ext := filepath.Ext(relPath)
+	base := strings.TrimSuffix(filepath.Base(relPath), ext)
+	hashedName := fmt.Sprintf("%s.%s%s", base, hex.EncodeToString(sum[:])[:8], ext)
+	hashedRelPath = filepath.Join(filepath.Dir(relPath), hashedName)
+
+	if err := ioutil.WriteFile(filepath.Join(destDir, hashedName), data, 0644); err != nil {
+		return "", fmt.Errorf("error writing fingerprinted asset %s: %v", hashedRelPath, err)
+	}
+
+	assetHashes.Lock()
+	assetHashes.m[relPath] = assetInfo{
+		hashedPath: hashedRelPath,
+		integrity:  "sha256-" + base64.StdEncoding.EncodeToString(sum[:]),
+	}
+	assetHashes.Unlock()
+	return hashedRelPath, nil
+}
+
+// fingerprintStaticAssets walks siteInfo.StaticFolder and fingerprints every
+// file it finds into siteInfo.Destination, keyed by its path relative to
+// StaticFolder (e.g. "css/main.css"). It runs before templates are executed
+// so the `asset` template func can resolve site-wide CSS/JS to their hashed
+// names. A site with no StaticFolder configured has nothing to do.
+func fingerprintStaticAssets(siteInfo *config.SiteInformation) error {
+	if siteInfo.StaticFolder == "" {
+		return nil
+	}
+	return filepath.Walk(siteInfo.StaticFolder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(siteInfo.StaticFolder, path)
+		if err != nil {
+			return fmt.Errorf("error resolving relative path for %s: %v", path, err)
+		}
+		destDir := filepath.Join(siteInfo.Destination, filepath.Dir(relPath))
+		if err := fs.CreateFolderIfNotExist(destDir); err != nil {
+			return fmt.Errorf("error creating asset directory %s: %v", destDir, err)
+		}
+		_, err = fingerprintFile(path, destDir, relPath)
+		return err
+	})
+}
+
+// rewriteAssetReferences rewrites `<link href="...">`, `<script src="...">`
+// and `<img src="...">` references inside html to point at their
+// fingerprinted paths, adding `integrity`/`crossorigin` to link and script
+// tags so browsers can verify them via Subresource Integrity. Image and
+// artifact references are resolved relative to basePath (the post's own
+// output directory, e.g. "hello-world"), matching how fingerprintFile keyed
+// them when copyImagesDir/copyAdditionalArtifacts ran.
+func rewriteAssetReferences(html []byte, basePath string) ([]byte, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(html)))
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing html for asset rewriting: %v", err)
+	}
+	doc.Find("link[href], script[src], img[src]").Each(func(i int, s *goquery.Selection) {
+		attr := "href"
+		orig, ok := s.Attr("href")
+		if !ok {
+			attr = "src"
+			orig, ok = s.Attr("src")
+		}
+		if !ok {
+			return
+		}
+
+		assetHashes.Lock()
+		info, hashed := assetHashes.m[filepath.Join(basePath, orig)]
+		assetHashes.Unlock()
+		if !hashed {
+			return
+		}
+		rel, err := filepath.Rel(basePath, info.hashedPath)
+		if err != nil {
+			return
+		}
+		s.SetAttr(attr, rel)
+		if attr == "href" || s.Is("script") {
+			s.SetAttr("integrity", info.integrity)
+			s.SetAttr("crossorigin", "anonymous")
+		}
+	})
+	out, err := doc.Html()
+	if err != nil {
+		return nil, fmt.Errorf("error while generating html after asset rewriting: %v", err)
+	}
+	out = strings.Replace(out, "<html><head></head><body>", "", 1)
+	out = strings.Replace(out, "</body></html>", "", 1)
+	return []byte(out), nil
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFingerprintFileAndRewrite(t *testing.T) {
+	destDir := t.TempDir()
+	srcDir := t.TempDir()
+
+	srcFile := filepath.Join(srcDir, "foo.png")
+	if err := ioutil.WriteFile(srcFile, []byte("fake png bytes"), 0644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	hashedRelPath, err := fingerprintFile(srcFile, destDir, filepath.Join("hello-world", "images", "foo.png"))
+	if err != nil {
+		t.Fatalf("fingerprintFile returned error: %v", err)
+	}
+	if hashedRelPath == filepath.Join("hello-world", "images", "foo.png") {
+		t.Fatalf("hashedRelPath %q was not fingerprinted", hashedRelPath)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, filepath.Base(hashedRelPath))); err != nil {
+		t.Fatalf("fingerprinted file missing on disk: %v", err)
+	}
+
+	html := []byte(`<html><head></head><body><img src="images/foo.png"></body></html>`)
+	out, err := rewriteAssetReferences(html, "hello-world")
+	if err != nil {
+		t.Fatalf("rewriteAssetReferences returned error: %v", err)
+	}
+	if strings.Contains(string(out), `src="images/foo.png"`) {
+		t.Fatalf("rewriteAssetReferences(%q) = %q, still references the unhashed path", html, out)
+	}
+	if !strings.Contains(string(out), filepath.Base(hashedRelPath)) {
+		t.Fatalf("rewriteAssetReferences(%q) = %q, want a reference to %s", html, out, filepath.Base(hashedRelPath))
+	}
+}
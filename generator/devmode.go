@@ -0,0 +1,27 @@
+package generator
+
+// liveReloadScript is injected into generated posts when dev mode is active.
+// It subscribes to the dev server's SSE endpoint and reloads the page whenever
+// a rebuild notification arrives.
+const liveReloadScript = `<script>
+(function() {
+	var source = new EventSource("/_livereload");
+	source.onmessage = function() {
+		location.reload();
+	};
+})();
+</script>`
+
+// DevMode enables live-reload instrumentation of generated HTML. It is
+// turned on by the devserver package for the lifetime of a `blog-gen serve`
+// run and must stay off for regular one-shot builds.
+var DevMode = false
+
+// injectLiveReload appends the live-reload script to a rendered post body
+// when DevMode is enabled, leaving the markup untouched otherwise.
+func injectLiveReload(html []byte) []byte {
+	if !DevMode {
+		return html
+	}
+	return append(html, []byte(liveReloadScript)...)
+}
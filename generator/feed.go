@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/RomanosTrechlis/blog-gen/config"
+)
+
+// atomFeed is the root element of an Atom feed document.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Link    atomLink `xml:"link"`
+	Updated string   `xml:"updated"`
+	Summary string   `xml:"summary"`
+	Content atomHTML `xml:"content"`
+}
+
+type atomHTML struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",cdata"`
+}
+
+// rssFeed is the root element of an RSS 2.0 feed document.
+type rssFeed struct {
+	XMLName xml.Name  `xml:"rss"`
+	Version string    `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title string    `xml:"title"`
+	Link  string    `xml:"link"`
+	Desc  string    `xml:"description"`
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Description cdata  `xml:"description"`
+}
+
+// cdata wraps a string so encoding/xml emits it inside a CDATA section
+// instead of escaping it, matching how atomHTML carries a post's rendered
+// HTML in the Atom feed.
+type cdata struct {
+	Body string `xml:",cdata"`
+}
+
+// feedGenerator emits atom.xml and rss.xml for the full set of posts.
+type feedGenerator struct {
+	posts       []*post
+	siteInfo    *config.SiteInformation
+	destination string
+}
+
+// Generate writes atom.xml and rss.xml into the destination directory.
+func (g *feedGenerator) Generate() (err error) {
+	fmt.Println("\tGenerating feeds...")
+	if err = g.generateAtom(); err != nil {
+		return err
+	}
+	if err = g.generateRSS(); err != nil {
+		return err
+	}
+	fmt.Println("\tFinished generating feeds...")
+	return nil
+}
+
+func (g *feedGenerator) generateAtom() error {
+	feed := atomFeed{
+		Xmlns:  "http://www.w3.org/2005/Atom",
+		Title:  g.siteInfo.Title,
+		ID:     g.siteInfo.BaseURL,
+		Link:   atomLink{Href: g.siteInfo.BaseURL + "/atom.xml", Rel: "self"},
+		Author: atomAuthor{Name: g.siteInfo.Author},
+	}
+	for i, p := range g.posts {
+		if i == 0 {
+			feed.Updated = p.meta.ParsedDate.Format(time.RFC3339)
+		}
+		link := g.postURL(p)
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   p.meta.Title,
+			ID:      link,
+			Link:    atomLink{Href: link},
+			Updated: p.meta.ParsedDate.Format(time.RFC3339),
+			Summary: p.meta.Description,
+			Content: atomHTML{Type: "html", Body: string(p.html)},
+		})
+	}
+	return writeXMLFile(filepath.Join(g.destination, "atom.xml"), feed)
+}
+
+func (g *feedGenerator) generateRSS() error {
+	channel := rssChannel{
+		Title: g.siteInfo.Title,
+		Link:  g.siteInfo.BaseURL,
+		Desc:  g.siteInfo.Description,
+	}
+	for _, p := range g.posts {
+		link := g.postURL(p)
+		channel.Items = append(channel.Items, rssItem{
+			Title:       p.meta.Title,
+			Link:        link,
+			GUID:        link,
+			PubDate:     p.meta.ParsedDate.Format(time.RFC1123Z),
+			Description: cdata{Body: string(p.html)},
+		})
+	}
+	feed := rssFeed{Version: "2.0", Channel: channel}
+	return writeXMLFile(filepath.Join(g.destination, "rss.xml"), feed)
+}
+
+func (g *feedGenerator) postURL(p *post) string {
+	return fmt.Sprintf("%s/%s", g.siteInfo.BaseURL, p.name)
+}
+
+func writeXMLFile(path string, v interface{}) error {
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling %s: %v", path, err)
+	}
+	out = append([]byte(xml.Header), out...)
+	if err := ioutil.WriteFile(path, out, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RomanosTrechlis/blog-gen/config"
+)
+
+func TestFeedGeneratorGenerate(t *testing.T) {
+	dir := t.TempDir()
+	siteInfo := &config.SiteInformation{
+		Title:       "My Blog",
+		BaseURL:     "https://example.com",
+		Author:      "Jane Doe",
+		Description: "A blog about things",
+	}
+	posts := []*post{
+		{
+			name: "hello-world",
+			html: []byte("<p>Hi</p>"),
+			meta: &Meta{Title: "Hello World", ParsedDate: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	g := &feedGenerator{posts: posts, siteInfo: siteInfo, destination: dir}
+
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	atomData, err := ioutil.ReadFile(filepath.Join(dir, "atom.xml"))
+	if err != nil {
+		t.Fatalf("reading atom.xml: %v", err)
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(atomData, &feed); err != nil {
+		t.Fatalf("unmarshalling atom.xml: %v", err)
+	}
+	if len(feed.Entries) != 1 || feed.Entries[0].Title != "Hello World" {
+		t.Fatalf("atom feed entries = %+v, want one entry titled Hello World", feed.Entries)
+	}
+	if feed.Entries[0].Link.Href != "https://example.com/hello-world" {
+		t.Errorf("entry link = %q, want canonical post URL", feed.Entries[0].Link.Href)
+	}
+
+	rssData, err := ioutil.ReadFile(filepath.Join(dir, "rss.xml"))
+	if err != nil {
+		t.Fatalf("reading rss.xml: %v", err)
+	}
+	var rss rssFeed
+	if err := xml.Unmarshal(rssData, &rss); err != nil {
+		t.Fatalf("unmarshalling rss.xml: %v", err)
+	}
+	if len(rss.Channel.Items) != 1 || rss.Channel.Items[0].Title != "Hello World" {
+		t.Fatalf("rss items = %+v, want one item titled Hello World", rss.Channel.Items)
+	}
+	if got := rss.Channel.Items[0].Description.Body; got != "<p>Hi</p>" {
+		t.Errorf("rss item description = %q, want unescaped post HTML %q", got, "<p>Hi</p>")
+	}
+}
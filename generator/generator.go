@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/RomanosTrechlis/blog-gen/config"
+)
+
+// Generate builds the full site described by siteInfo: it collects every
+// post under siteInfo.ContentFolder, renders each one, and writes the
+// result to siteInfo.Destination.
+func Generate(siteInfo *config.SiteInformation) error {
+	if err := fingerprintStaticAssets(siteInfo); err != nil {
+		return err
+	}
+	temp, err := parseTemplates(siteInfo.TemplateFolder)
+	if err != nil {
+		return err
+	}
+
+	posts, err := collectPosts(siteInfo)
+	if err != nil {
+		return err
+	}
+	sort.Sort(byDateDesc(posts))
+
+	gens := make([]*postGenerator, len(posts))
+	for i, p := range posts {
+		gens[i] = &postGenerator{post: p, siteInfo: siteInfo, template: temp, destination: siteInfo.Destination}
+	}
+	if err := generatePosts(gens, siteInfo.PoolSize); err != nil {
+		return err
+	}
+
+	feeds := &feedGenerator{posts: posts, siteInfo: siteInfo, destination: siteInfo.Destination}
+	if err := feeds.Generate(); err != nil {
+		return err
+	}
+	sitemap := &sitemapGenerator{posts: posts, siteInfo: siteInfo, destination: siteInfo.Destination}
+	if err := sitemap.Generate(); err != nil {
+		return err
+	}
+	tags := &tagsGenerator{posts: posts, siteInfo: siteInfo, template: temp, destination: siteInfo.Destination}
+	return tags.Generate()
+}
+
+// GeneratePost rebuilds a single post under postDir and writes it to
+// siteInfo.Destination, without touching the rest of the site. It backs the
+// devserver's incremental rebuild path.
+func GeneratePost(siteInfo *config.SiteInformation, postDir string) error {
+	temp, err := parseTemplates(siteInfo.TemplateFolder)
+	if err != nil {
+		return err
+	}
+	p, err := buildPost(postDir, siteInfo)
+	if err != nil {
+		return err
+	}
+	g := &postGenerator{post: p, siteInfo: siteInfo, template: temp, destination: siteInfo.Destination}
+	return g.Generate()
+}
+
+// parseTemplates parses every *.html template under templateFolder, with the
+// `asset` template func registered so layouts can write
+// `{{ asset "css/main.css" }}` to reference a fingerprinted static asset.
+func parseTemplates(templateFolder string) (*template.Template, error) {
+	temp, err := template.New(filepath.Base(templateFolder)).
+		Funcs(template.FuncMap{"asset": asset}).
+		ParseGlob(filepath.Join(templateFolder, "*.html"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing templates in %s: %v", templateFolder, err)
+	}
+	return temp, nil
+}
+
+// collectPosts builds a *post for every subdirectory of
+// siteInfo.ContentFolder.
+func collectPosts(siteInfo *config.SiteInformation) ([]*post, error) {
+	entries, err := ioutil.ReadDir(siteInfo.ContentFolder)
+	if err != nil {
+		return nil, fmt.Errorf("error reading content folder %s: %v", siteInfo.ContentFolder, err)
+	}
+	posts := make([]*post, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		p, err := buildPost(filepath.Join(siteInfo.ContentFolder, entry.Name()), siteInfo)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+	return posts, nil
+}
+
+// buildPost reads one post directory into a *post, named after the
+// directory it was read from.
+func buildPost(dir string, siteInfo *config.SiteInformation) (*post, error) {
+	html, front, err := getHTML(dir, siteInfo)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := newMeta(front)
+	if err != nil {
+		return nil, fmt.Errorf("error reading front-matter in %s: %v", dir, err)
+	}
+	imagesDir, images, err := getImages(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &post{
+		name:      filepath.Base(dir),
+		html:      html,
+		meta:      meta,
+		imagesDir: imagesDir,
+		images:    images,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/sourcegraph/syntaxhighlight"
+	"github.com/yuin/goldmark"
+)
+
+// HighlighterChroma renders fenced code blocks with goldmark's own
+// chroma-backed highlighting extension, entirely during the goldmark pass.
+const HighlighterChroma = "chroma"
+
+// HighlighterLegacy keeps the pre-goldmark behaviour of highlighting code
+// blocks as a post-processing pass over the rendered HTML, so existing
+// sites that depend on its output aren't forced onto chroma.
+const HighlighterLegacy = "legacy"
+
+// highlighterExtension returns the goldmark extension for name, or nil when
+// the highlighter is applied as a post-processing step instead.
+func highlighterExtension(name string) goldmark.Extender {
+	if name != HighlighterLegacy {
+		return highlighting.NewHighlighting(highlighting.WithStyle("monokai"))
+	}
+	return nil
+}
+
+// LegacyHighlight highlights `<code class="language-*">` blocks in place
+// using sourcegraph/syntaxhighlight, mirroring the highlighter blog-gen
+// used before goldmark. It is the renderer's HighlighterLegacy option.
+func LegacyHighlight(html []byte) ([]byte, error) {
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing html: %v", err)
+	}
+	doc.Find("code[class*=\"language-\"]").Each(func(i int, s *goquery.Selection) {
+		oldCode := s.Text()
+		formatted, _ := syntaxhighlight.AsHTML([]byte(oldCode))
+		s.SetHtml(string(formatted))
+	})
+	out, err := doc.Html()
+	if err != nil {
+		return nil, fmt.Errorf("error while generating html: %v", err)
+	}
+	out = strings.Replace(out, "<html><head></head><body>", "", 1)
+	out = strings.Replace(out, "</body></html>", "", 1)
+	return []byte(out), nil
+}
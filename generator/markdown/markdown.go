@@ -0,0 +1,71 @@
+// Package markdown renders post content to HTML. It wraps goldmark behind a
+// small Renderer interface so the set of enabled extensions — and the code
+// highlighter used for fenced code blocks — can be swapped per site.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/RomanosTrechlis/blog-gen/config"
+	emoji "github.com/yuin/goldmark-emoji"
+	meta "github.com/yuin/goldmark-meta"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	mermaid "go.abhg.dev/goldmark/mermaid"
+)
+
+// Renderer turns Markdown source into HTML.
+type Renderer interface {
+	// Render converts the given Markdown source to HTML and returns any
+	// YAML front-matter found at the top of the document.
+	Render(src []byte) (html []byte, front map[string]interface{}, err error)
+}
+
+// goldmarkRenderer is the default Renderer, backed by yuin/goldmark.
+type goldmarkRenderer struct {
+	md goldmark.Markdown
+}
+
+// New builds a Renderer configured from the extensions enabled on siteInfo.
+// GFM (tables, strikethrough, task lists), front-matter parsing and heading
+// IDs are always on; emoji and Mermaid are opt-in so existing sites don't
+// change output unless they ask for it.
+func New(siteInfo *config.SiteInformation) Renderer {
+	exts := []goldmark.Extender{extension.GFM, meta.Meta}
+	if siteInfo.MarkdownExtensions.Emoji {
+		exts = append(exts, emoji.Emoji)
+	}
+	if siteInfo.MarkdownExtensions.Mermaid {
+		exts = append(exts, &mermaid.Extender{})
+	}
+	if ext := highlighterExtension(siteInfo.MarkdownExtensions.Highlighter); ext != nil {
+		exts = append(exts, ext)
+	}
+
+	md := goldmark.New(
+		goldmark.WithExtensions(exts...),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
+		),
+		// WithUnsafe preserves raw HTML passthrough (e.g. embedded <img>,
+		// <div>, iframes) that blackfriday allowed by default; goldmark
+		// strips raw HTML unless told otherwise.
+		goldmark.WithRendererOptions(
+			html.WithUnsafe(),
+		),
+	)
+	return &goldmarkRenderer{md: md}
+}
+
+func (r *goldmarkRenderer) Render(src []byte) ([]byte, map[string]interface{}, error) {
+	ctx := parser.NewContext()
+	var buf bytes.Buffer
+	if err := r.md.Convert(src, &buf, parser.WithContext(ctx)); err != nil {
+		return nil, nil, fmt.Errorf("error rendering markdown: %v", err)
+	}
+	front := meta.Get(ctx)
+	return buf.Bytes(), front, nil
+}
@@ -0,0 +1,50 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RomanosTrechlis/blog-gen/config"
+)
+
+func TestRenderFrontMatter(t *testing.T) {
+	src := []byte("---\ntitle: Hello\ntags: [go, testing]\n---\n# Hi\n")
+	r := New(&config.SiteInformation{})
+
+	html, front, err := r.Render(src)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if len(html) == 0 {
+		t.Fatal("Render returned empty html")
+	}
+	if got := front["title"]; got != "Hello" {
+		t.Fatalf("front[\"title\"] = %v, want %q", got, "Hello")
+	}
+}
+
+func TestRenderGFM(t *testing.T) {
+	src := []byte("| a | b |\n|---|---|\n| 1 | 2 |\n")
+	r := New(&config.SiteInformation{})
+
+	html, _, err := r.Render(src)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if !strings.Contains(string(html), "<table>") {
+		t.Fatalf("Render(%q) = %q, want a <table>", src, html)
+	}
+}
+
+func TestRenderPreservesRawHTML(t *testing.T) {
+	src := []byte(`<div class="callout">hi</div>` + "\n")
+	r := New(&config.SiteInformation{})
+
+	html, _, err := r.Render(src)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if !strings.Contains(string(html), `<div class="callout">`) {
+		t.Fatalf("Render(%q) = %q, want the raw <div> preserved", src, html)
+	}
+}
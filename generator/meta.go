@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateLayout is the format post front-matter dates are written in, e.g.
+// `date: 2026-07-27`.
+const dateLayout = "2006-01-02"
+
+// Meta holds the front-matter fields of a post.
+type Meta struct {
+	Title       string
+	ParsedDate  time.Time
+	Description string
+	Tags        []string
+	Categories  []string
+}
+
+// newMeta builds a Meta from the YAML front-matter goldmark-meta parsed out
+// of a post's Markdown source.
+func newMeta(front map[string]interface{}) (*Meta, error) {
+	m := &Meta{}
+	if title, ok := front["title"].(string); ok {
+		m.Title = title
+	}
+	if desc, ok := front["description"].(string); ok {
+		m.Description = desc
+	}
+	if date, ok := front["date"].(string); ok {
+		parsed, err := time.Parse(dateLayout, date)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing date %q: %v", date, err)
+		}
+		m.ParsedDate = parsed
+	}
+	m.Tags = stringList(front["tags"])
+	m.Categories = stringList(front["categories"])
+	return m, nil
+}
+
+// stringList normalizes a front-matter value declared as a YAML list (e.g.
+// `tags: [go, testing]`) into a []string, ignoring non-list values.
+func stringList(v interface{}) []string {
+	raw, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package generator
+
+import "testing"
+
+func TestNewMeta(t *testing.T) {
+	front := map[string]interface{}{
+		"title":       "Hello",
+		"description": "A post",
+		"date":        "2026-07-27",
+		"tags":        []interface{}{"go", "testing"},
+		"categories":  []interface{}{"dev"},
+	}
+
+	m, err := newMeta(front)
+	if err != nil {
+		t.Fatalf("newMeta returned error: %v", err)
+	}
+	if m.Title != "Hello" {
+		t.Errorf("Title = %q, want %q", m.Title, "Hello")
+	}
+	if m.ParsedDate.Format(dateLayout) != "2026-07-27" {
+		t.Errorf("ParsedDate = %v, want 2026-07-27", m.ParsedDate)
+	}
+	if len(m.Tags) != 2 || m.Tags[0] != "go" || m.Tags[1] != "testing" {
+		t.Errorf("Tags = %v, want [go testing]", m.Tags)
+	}
+	if len(m.Categories) != 1 || m.Categories[0] != "dev" {
+		t.Errorf("Categories = %v, want [dev]", m.Categories)
+	}
+}
+
+func TestNewMetaInvalidDate(t *testing.T) {
+	if _, err := newMeta(map[string]interface{}{"date": "not-a-date"}); err == nil {
+		t.Fatal("newMeta with an invalid date should return an error")
+	}
+}
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"runtime"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultPoolSize bounds concurrent post generation when the site hasn't
+// configured an explicit worker count.
+func defaultPoolSize(configured int) int {
+	if configured > 0 {
+		return configured
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// generatePosts runs gens concurrently, bounded to poolSize workers at a
+// time, and returns the first error encountered. Markdown rendering, goquery
+// parsing, syntax highlighting and file I/O are all independent per post, so
+// this turns the build's dominant cost into a roughly linear function of
+// core count instead of post count.
+func generatePosts(gens []*postGenerator, poolSize int) error {
+	var g errgroup.Group
+	g.SetLimit(defaultPoolSize(poolSize))
+	for _, pg := range gens {
+		pg := pg
+		g.Go(func() error {
+			return pg.Generate()
+		})
+	}
+	return g.Wait()
+}
@@ -0,0 +1,12 @@
+package generator
+
+import "testing"
+
+func TestDefaultPoolSize(t *testing.T) {
+	if got := defaultPoolSize(4); got != 4 {
+		t.Errorf("defaultPoolSize(4) = %d, want 4", got)
+	}
+	if got := defaultPoolSize(0); got <= 0 {
+		t.Errorf("defaultPoolSize(0) = %d, want a positive GOMAXPROCS fallback", got)
+	}
+}
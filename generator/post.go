@@ -1,20 +1,16 @@
 package generator
 
 import (
-	"bytes"
 	"fmt"
 	"html/template"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/RomanosTrechlis/blog-gen/config"
+	"github.com/RomanosTrechlis/blog-gen/generator/markdown"
 	"github.com/RomanosTrechlis/blog-gen/util/fs"
 	"github.com/RomanosTrechlis/blog-gen/util/url"
-	"github.com/russross/blackfriday"
-	"github.com/sourcegraph/syntaxhighlight"
 )
 
 // post holds data for a post
@@ -52,6 +48,15 @@ func (g *postGenerator) Generate() (err error) {
 			return err
 		}
 	}
+	err = g.copyAdditionalArtifacts(staticPath, post.name)
+	if err != nil {
+		return err
+	}
+
+	content, err := rewriteAssetReferences(post.html, post.name)
+	if err != nil {
+		return err
+	}
 
 	c := htmlConfig{
 		path:       url.ChangePathToUrl(staticPath),
@@ -60,18 +65,13 @@ func (g *postGenerator) Generate() (err error) {
 		maxPageNum: 0,
 		isPost:     true,
 		temp:       g.template,
-		content:    template.HTML(string(post.html)),
+		content:    template.HTML(string(content)),
 		siteInfo:   g.siteInfo,
 	}
 	err = c.writeHTML()
 	if err != nil {
 		return err
 	}
-
-	err = g.copyAdditionalArtifacts(staticPath, post.name)
-	if err != nil {
-		return err
-	}
 	fmt.Printf("\tFinished generating Post: %s...\n", post.meta.Title)
 	return nil
 }
@@ -83,16 +83,16 @@ func (g *postGenerator) copyAdditionalArtifacts(path, postName string) (err erro
 		return nil
 	}
 	for _, file := range files {
-		src = filepath.Join(src, file.Name())
-		err := fs.CopyFile(src, path)
-		if err != nil {
+		f := filepath.Join(src, file.Name())
+		relPath := filepath.Join(postName, file.Name())
+		if _, err := fingerprintFile(f, path, relPath); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (*postGenerator) copyImagesDir(source, destination string) (err error) {
+func (g *postGenerator) copyImagesDir(source, destination string) (err error) {
 	path := filepath.Join(destination, "images")
 	err = fs.CreateFolderIfNotExist(path)
 	if err != nil {
@@ -104,27 +104,35 @@ func (*postGenerator) copyImagesDir(source, destination string) (err error) {
 	}
 	for _, file := range files {
 		src := filepath.Join(source, file.Name())
-		err := fs.CopyFile(src, path)
+		relPath, err := filepath.Rel(g.destination, path)
 		if err != nil {
+			return fmt.Errorf("error resolving relative path for %s: %v", path, err)
+		}
+		if _, err := fingerprintFile(src, path, filepath.Join(relPath, file.Name())); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func getHTML(path string) (html []byte, err error) {
+func getHTML(path string, siteInfo *config.SiteInformation) (html []byte, front map[string]interface{}, err error) {
 	filePath := filepath.Join(path, "post.md")
 	input, err := ioutil.ReadFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("error while reading file %s: %v", filePath, err)
+		return nil, nil, fmt.Errorf("error while reading file %s: %v", filePath, err)
 	}
-	html = blackfriday.MarkdownCommon(input)
-	replaced, err := replaceCodeParts(html)
+	html, front, err = markdown.New(siteInfo).Render(input)
 	if err != nil {
-		return nil, fmt.Errorf("error during syntax highlighting of %s: %v", filePath, err)
+		return nil, nil, fmt.Errorf("error during markdown rendering of %s: %v", filePath, err)
+	}
+	if siteInfo.MarkdownExtensions.Highlighter == markdown.HighlighterLegacy {
+		html, err = markdown.LegacyHighlight(html)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error during syntax highlighting of %s: %v", filePath, err)
+		}
 	}
-	html = []byte(replaced)
-	return html, nil
+	html = injectLiveReload(html)
+	return html, front, nil
 }
 
 func getImages(path string) (dirPath string, images []string, err error) {
@@ -143,28 +151,6 @@ func getImages(path string) (dirPath string, images []string, err error) {
 	return dirPath, images, nil
 }
 
-func replaceCodeParts(htmlFile []byte) (new string, err error) {
-	byteReader := bytes.NewReader(htmlFile)
-	doc, err := goquery.NewDocumentFromReader(byteReader)
-	if err != nil {
-		return "", fmt.Errorf("error while parsing html: %v", err)
-	}
-	// find code-parts via css selector and replace them with highlighted versions
-	doc.Find("code[class*=\"language-\"]").Each(func(i int, s *goquery.Selection) {
-		oldCode := s.Text()
-		formatted, _ := syntaxhighlight.AsHTML([]byte(oldCode))
-		s.SetHtml(string(formatted))
-	})
-	new, err = doc.Html()
-	if err != nil {
-		return "", fmt.Errorf("error while generating html: %v", err)
-	}
-	// replace unnecessarily added html tags
-	new = strings.Replace(new, "<html><head></head><body>", "", 1)
-	new = strings.Replace(new, "</body></html>", "", 1)
-	return new, nil
-}
-
 func (p byDateDesc) Len() int {
 	return len(p)
 }
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/RomanosTrechlis/blog-gen/config"
+	"github.com/RomanosTrechlis/blog-gen/util/url"
+)
+
+// urlSet is the root element of a sitemap.xml document.
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+}
+
+// sitemapGenerator emits sitemap.xml for every post plus the index pages.
+type sitemapGenerator struct {
+	posts       []*post
+	siteInfo    *config.SiteInformation
+	destination string
+}
+
+// Generate writes sitemap.xml into the destination directory.
+func (g *sitemapGenerator) Generate() (err error) {
+	fmt.Println("\tGenerating sitemap...")
+	set := urlSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	set.URLs = append(set.URLs, sitemapURL{
+		Loc:        g.siteInfo.BaseURL,
+		LastMod:    time.Now().Format("2006-01-02"),
+		ChangeFreq: "daily",
+	})
+	for _, p := range g.posts {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:        fmt.Sprintf("%s/%s", g.siteInfo.BaseURL, p.name),
+			LastMod:    p.meta.ParsedDate.Format("2006-01-02"),
+			ChangeFreq: "monthly",
+		})
+	}
+
+	now := time.Now().Format("2006-01-02")
+	set.URLs = append(set.URLs, sitemapURL{
+		Loc:        fmt.Sprintf("%s/tags/", g.siteInfo.BaseURL),
+		LastMod:    now,
+		ChangeFreq: "weekly",
+	})
+	for _, slug := range uniqueSlugs(g.posts, func(m *Meta) []string { return m.Tags }) {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:        fmt.Sprintf("%s/tags/%s/", g.siteInfo.BaseURL, slug),
+			LastMod:    now,
+			ChangeFreq: "weekly",
+		})
+	}
+	for _, slug := range uniqueSlugs(g.posts, func(m *Meta) []string { return m.Categories }) {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:        fmt.Sprintf("%s/categories/%s/", g.siteInfo.BaseURL, slug),
+			LastMod:    now,
+			ChangeFreq: "weekly",
+		})
+	}
+
+	if err = writeXMLFile(filepath.Join(g.destination, "sitemap.xml"), set); err != nil {
+		return err
+	}
+	fmt.Println("\tFinished generating sitemap...")
+	return nil
+}
+
+// uniqueSlugs returns the sorted, de-duplicated URL slugs of the values
+// field extracts from each post's Meta (tags or categories).
+func uniqueSlugs(posts []*post, field func(*Meta) []string) []string {
+	seen := map[string]bool{}
+	var slugs []string
+	for _, p := range posts {
+		for _, name := range field(p.meta) {
+			slug := url.ChangePathToUrl(name)
+			if !seen[slug] {
+				seen[slug] = true
+				slugs = append(slugs, slug)
+			}
+		}
+	}
+	sort.Strings(slugs)
+	return slugs
+}
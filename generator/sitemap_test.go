@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RomanosTrechlis/blog-gen/config"
+)
+
+func TestSitemapGeneratorGenerate(t *testing.T) {
+	dir := t.TempDir()
+	siteInfo := &config.SiteInformation{BaseURL: "https://example.com"}
+	posts := []*post{
+		{
+			name: "hello-world",
+			meta: &Meta{
+				Title:      "Hello World",
+				ParsedDate: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+				Tags:       []string{"go"},
+				Categories: []string{"dev"},
+			},
+		},
+	}
+	g := &sitemapGenerator{posts: posts, siteInfo: siteInfo, destination: dir}
+
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "sitemap.xml"))
+	if err != nil {
+		t.Fatalf("reading sitemap.xml: %v", err)
+	}
+	var set urlSet
+	if err := xml.Unmarshal(data, &set); err != nil {
+		t.Fatalf("unmarshalling sitemap.xml: %v", err)
+	}
+
+	want := map[string]bool{
+		"https://example.com":                 false,
+		"https://example.com/hello-world":     false,
+		"https://example.com/tags/":           false,
+		"https://example.com/tags/go/":        false,
+		"https://example.com/categories/dev/": false,
+	}
+	for _, u := range set.URLs {
+		if _, ok := want[u.Loc]; ok {
+			want[u.Loc] = true
+		}
+	}
+	for loc, found := range want {
+		if !found {
+			t.Errorf("sitemap.xml missing expected <loc>%s</loc>", loc)
+		}
+	}
+}
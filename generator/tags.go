@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"sort"
+
+	"github.com/RomanosTrechlis/blog-gen/config"
+	"github.com/RomanosTrechlis/blog-gen/util/url"
+)
+
+// tagCount is one entry in the tag cloud, sized by how many posts use it.
+type tagCount struct {
+	Slug  string
+	Name  string
+	Count int
+}
+
+// tagGroup is every post that shares a tag or category, keeping the
+// human-readable name (e.g. "Go") alongside the posts keyed by its slug.
+type tagGroup struct {
+	name  string
+	posts []*post
+}
+
+// tagsGenerator emits per-tag and per-category listing pages, plus a tag
+// cloud page, from the tags/categories declared in each post's front-matter.
+type tagsGenerator struct {
+	posts       []*post
+	siteInfo    *config.SiteInformation
+	template    *template.Template
+	destination string
+}
+
+// Generate writes /tags/<slug>/, /categories/<slug>/ and /tags/ into the
+// destination directory.
+func (g *tagsGenerator) Generate() (err error) {
+	fmt.Println("\tGenerating tag and category pages...")
+	if err = g.generateGroup("tags", g.groupBy(func(m *Meta) []string { return m.Tags })); err != nil {
+		return err
+	}
+	if err = g.generateGroup("categories", g.groupBy(func(m *Meta) []string { return m.Categories })); err != nil {
+		return err
+	}
+	if err = g.generateCloud(); err != nil {
+		return err
+	}
+	fmt.Println("\tFinished generating tag and category pages...")
+	return nil
+}
+
+// groupBy buckets posts by the slugified values returned by field, keeping
+// the human-readable name each slug was derived from.
+func (g *tagsGenerator) groupBy(field func(*Meta) []string) map[string]*tagGroup {
+	groups := map[string]*tagGroup{}
+	for _, p := range g.posts {
+		for _, name := range field(p.meta) {
+			slug := url.ChangePathToUrl(name)
+			grp, ok := groups[slug]
+			if !ok {
+				grp = &tagGroup{name: name}
+				groups[slug] = grp
+			}
+			grp.posts = append(grp.posts, p)
+		}
+	}
+	return groups
+}
+
+func (g *tagsGenerator) generateGroup(section string, groups map[string]*tagGroup) error {
+	for slug, grp := range groups {
+		path := filepath.Join(g.destination, section, slug)
+		html := g.listHTML(grp.posts)
+		c := htmlConfig{
+			path:       url.ChangePathToUrl(path),
+			pageTitle:  grp.name,
+			pageNum:    0,
+			maxPageNum: 0,
+			isPost:     false,
+			temp:       g.template,
+			content:    template.HTML(html),
+			siteInfo:   g.siteInfo,
+		}
+		if err := c.writeHTML(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *tagsGenerator) generateCloud() error {
+	groups := g.groupBy(func(m *Meta) []string { return m.Tags })
+	cloud := make([]tagCount, 0, len(groups))
+	for slug, grp := range groups {
+		cloud = append(cloud, tagCount{Slug: slug, Name: grp.name, Count: len(grp.posts)})
+	}
+	sort.Slice(cloud, func(i, j int) bool { return cloud[i].Name < cloud[j].Name })
+
+	var html string
+	for _, tc := range cloud {
+		html += fmt.Sprintf(`<a href="/tags/%s/" class="tag-cloud-item" data-count="%d">%s</a>`,
+			tc.Slug, tc.Count, tc.Name)
+	}
+
+	path := filepath.Join(g.destination, "tags")
+	c := htmlConfig{
+		path:       url.ChangePathToUrl(path),
+		pageTitle:  "Tags",
+		pageNum:    0,
+		maxPageNum: 0,
+		isPost:     false,
+		temp:       g.template,
+		content:    template.HTML(html),
+		siteInfo:   g.siteInfo,
+	}
+	return c.writeHTML()
+}
+
+func (g *tagsGenerator) listHTML(posts []*post) string {
+	sort.Sort(byDateDesc(posts))
+	var html string
+	for _, p := range posts {
+		html += fmt.Sprintf(`<a href="/%s">%s</a>`, p.name, p.meta.Title)
+	}
+	return html
+}
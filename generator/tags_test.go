@@ -0,0 +1,29 @@
+package generator
+
+import "testing"
+
+func TestTagsGeneratorGroupByKeepsHumanReadableName(t *testing.T) {
+	posts := []*post{
+		{name: "a", meta: &Meta{Title: "A", Tags: []string{"Go"}}},
+		{name: "b", meta: &Meta{Title: "B", Tags: []string{"Go", "Testing"}}},
+	}
+	g := &tagsGenerator{posts: posts}
+
+	groups := g.groupBy(func(m *Meta) []string { return m.Tags })
+
+	goGroup, ok := groups["go"]
+	if !ok {
+		t.Fatalf("groups = %v, want a \"go\" slug", groups)
+	}
+	if goGroup.name != "Go" {
+		t.Errorf("goGroup.name = %q, want %q (the human-readable tag, not the slug)", goGroup.name, "Go")
+	}
+	if len(goGroup.posts) != 2 {
+		t.Errorf("len(goGroup.posts) = %d, want 2", len(goGroup.posts))
+	}
+
+	testingGroup, ok := groups["testing"]
+	if !ok || testingGroup.name != "Testing" || len(testingGroup.posts) != 1 {
+		t.Errorf("groups[\"testing\"] = %+v, want name Testing with 1 post", testingGroup)
+	}
+}